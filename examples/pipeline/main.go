@@ -0,0 +1,55 @@
+// Command pipeline demonstrates chaining independent workerpool.Pool stages
+// together: fetch -> transform -> write, each with its own concurrency
+// limit. The transform stage fans its work out across 3 subpools and fans
+// the results back in with workerpool.FanIn before handing them to write.
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/zhitoo/go-concurrency-patterns/workerpool"
+)
+
+func main() {
+	ctx := context.Background()
+
+	fetch := workerpool.New[int, int](3)
+	fetch.Run(ctx)
+	go func() {
+		for i := 1; i <= 10; i++ {
+			fetch.Submit(workerpool.NewTask(i, func(_ context.Context, in int) (int, error) {
+				time.Sleep(20 * time.Millisecond)
+				return in * 2, nil
+			}))
+		}
+		fetch.Close()
+	}()
+
+	toTransform := make(chan *workerpool.Task[int, int])
+	go func() {
+		for t := range fetch.Results() {
+			toTransform <- workerpool.NewTask(t.Result, func(_ context.Context, in int) (int, error) {
+				return in + 1, nil
+			})
+		}
+		close(toTransform)
+	}()
+	transformed := workerpool.FanIn(workerpool.FanOut(ctx, toTransform, 3)...)
+
+	write := workerpool.New[int, string](2)
+	write.Run(ctx)
+	go func() {
+		for t := range transformed {
+			write.Submit(workerpool.NewTask(t.Result, func(_ context.Context, in int) (string, error) {
+				return fmt.Sprintf("job result: %d", in), nil
+			}))
+		}
+		write.Close()
+	}()
+
+	for t := range write.Results() {
+		fmt.Println(t.Result)
+	}
+}
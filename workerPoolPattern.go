@@ -1,9 +1,11 @@
 package main
 
 import (
+	"context"
 	"fmt"
-	"sync"
 	"time"
+
+	"github.com/zhitoo/go-concurrency-patterns/workerpool"
 )
 
 /*
@@ -16,59 +18,32 @@ This pattern is valuable when you have a large number of tasks to execute.
 Some examples of using the Worker Pool Pattern in Real-world Applications:
 	- Handling incoming HTTP requests in a web server.
 	- Processing images concurrently.
-*/
 
-func worker(id int, jobs <-chan int, results chan<- int) {
-	for job := range jobs {
-		fmt.Printf("Worker %d processing job %d\n", id, job)
-		time.Sleep(time.Second * 1)
-		results <- job * 2
-	}
-}
+This demo now runs on top of the reusable workerpool package: see workerpool/
+for the Task/Worker/Pool types.
+*/
 
 func main() {
 	numJobs := 10
 	numWorkers := 3
 
-	jobs := make(chan int, numJobs)
-	results := make(chan int, numJobs)
-
-	/*
-		Using a WaitGroup in the original code allows you to:
-
-			- Wait until all workers finish processing.
-			- Safely close the results channel,
-			  which signals to the for range loop in the main function
-			  that no more results will come.
-	*/
-	var wg sync.WaitGroup
-
-	// Start worker goroutines
-	for i := 1; i <= numWorkers; i++ {
-		wg.Add(1)
-		go func(workerID int) {
-			defer wg.Done()
-			worker(workerID, jobs, results)
-		}(i)
-	}
+	pool := workerpool.New[int, int](numWorkers)
+	pool.Run(context.Background())
 
 	// Enqueue jobs
-	for i := 1; i <= numJobs; i++ {
-		jobs <- i
-	}
-	close(jobs)
-
-	// Wait for all workers to finish
 	go func() {
-		wg.Wait()
-		close(results)
+		for i := 1; i <= numJobs; i++ {
+			pool.Submit(workerpool.NewTask(i, func(_ context.Context, job int) (int, error) {
+				fmt.Printf("Processing job %d\n", job)
+				time.Sleep(time.Second * 1)
+				return job * 2, nil
+			}))
+		}
+		pool.Close()
 	}()
 
 	// Collect results
-	//the main goroutine will keep trying to read from results until it is closed
-	//Notice: The reason for the deadlock is because the results channel is never closed,
-	// and the main function is trying to range over it indefinitely.
-	for result := range results {
-		fmt.Printf("Result: %d\n", result)
+	for t := range pool.Results() {
+		fmt.Printf("Result: %d\n", t.Result)
 	}
 }
@@ -0,0 +1,69 @@
+package workerpool
+
+import (
+	"context"
+	"sync"
+)
+
+// FanIn merges an arbitrary number of input channels into a single output
+// channel. The output channel is closed once every input channel has been
+// drained and closed.
+func FanIn[T any](chs ...<-chan T) <-chan T {
+	out := make(chan T)
+
+	var wg sync.WaitGroup
+	wg.Add(len(chs))
+	for _, ch := range chs {
+		go func(ch <-chan T) {
+			defer wg.Done()
+			for v := range ch {
+				out <- v
+			}
+		}(ch)
+	}
+
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+
+	return out
+}
+
+// FanOut splits in across n independent workers, each running tasks with
+// their own Handler and publishing to its own output channel. It is a
+// standalone function rather than a Pool method: it doesn't create a "pool"
+// in any sense that owns worker goroutines across calls, and it does not run
+// tasks through any Pool's middleware (WithRetry/WithTimeout/WithRecover) —
+// if a task submitted here needs that behavior, compose it into the Task's
+// own Handler before calling FanOut. Combine the returned channels with
+// FanIn to merge them back into a single stream, or feed them into further
+// stages to keep the pipeline split. Every worker goroutine stops, closing
+// its output channel, once in closes or ctx is done.
+func FanOut[In, Out any](ctx context.Context, in <-chan *Task[In, Out], n int) []<-chan *Task[In, Out] {
+	outs := make([]<-chan *Task[In, Out], n)
+	for i := 0; i < n; i++ {
+		out := make(chan *Task[In, Out])
+		outs[i] = out
+		go func(out chan *Task[In, Out]) {
+			defer close(out)
+			for {
+				select {
+				case t, ok := <-in:
+					if !ok {
+						return
+					}
+					t.run(ctx)
+					select {
+					case out <- t:
+					case <-ctx.Done():
+						return
+					}
+				case <-ctx.Done():
+					return
+				}
+			}
+		}(out)
+	}
+	return outs
+}
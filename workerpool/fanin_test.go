@@ -0,0 +1,107 @@
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+	"time"
+)
+
+func TestFanIn(t *testing.T) {
+	a := make(chan int)
+	b := make(chan int)
+	go func() {
+		a <- 1
+		a <- 2
+		close(a)
+	}()
+	go func() {
+		b <- 3
+		close(b)
+	}()
+
+	var got []int
+	for v := range FanIn(a, b) {
+		got = append(got, v)
+	}
+	sort.Ints(got)
+	if len(got) != 3 || got[0] != 1 || got[1] != 2 || got[2] != 3 {
+		t.Fatalf("expected [1 2 3] in some order, got %v", got)
+	}
+}
+
+func TestFanOutFanIn(t *testing.T) {
+	in := make(chan *Task[int, int])
+	go func() {
+		for i := 1; i <= 10; i++ {
+			in <- NewTask(i, func(_ context.Context, n int) (int, error) {
+				return n * n, nil
+			})
+		}
+		close(in)
+	}()
+
+	merged := FanIn(FanOut(context.Background(), in, 3)...)
+
+	seen := map[int]int{}
+	for result := range merged {
+		seen[result.Input] = result.Result
+	}
+	if len(seen) != 10 {
+		t.Fatalf("expected 10 results, got %d", len(seen))
+	}
+	for i := 1; i <= 10; i++ {
+		if seen[i] != i*i {
+			t.Fatalf("task %d: want %d, got %d", i, i*i, seen[i])
+		}
+	}
+}
+
+// TestFanOutStopsOnCancel guards against FanOut's workers blocking forever
+// reading from an in channel that never closes (e.g. a cancelled upstream
+// stage) — each worker must also select on ctx.Done().
+func TestFanOutStopsOnCancel(t *testing.T) {
+	in := make(chan *Task[int, int]) // deliberately never closed or written to
+
+	ctx, cancel := context.WithCancel(context.Background())
+	outs := FanOut(ctx, in, 2)
+	cancel()
+
+	done := make(chan struct{})
+	go func() {
+		for _, out := range outs {
+			for range out {
+			}
+		}
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(500 * time.Millisecond):
+		t.Fatalf("FanOut workers did not stop after ctx was cancelled")
+	}
+}
+
+// TestFanOutHasNoMiddleware documents that FanOut, being a standalone
+// function with no Pool of its own, has no way to apply any Pool's
+// WithRetry/WithTimeout/WithRecover middleware. That's deliberate, not an
+// oversight: callers who need that behavior through FanOut must compose it
+// into the Task's own Handler instead.
+func TestFanOutHasNoMiddleware(t *testing.T) {
+	in := make(chan *Task[int, int], 1)
+	attempts := 0
+	in <- NewTask(1, func(_ context.Context, n int) (int, error) {
+		attempts++
+		return 0, errors.New("flaky")
+	})
+	close(in)
+
+	for range FanIn(FanOut(context.Background(), in, 1)...) {
+	}
+
+	if attempts != 1 {
+		t.Fatalf("expected FanOut to run the handler exactly once with no retries, got %d attempts", attempts)
+	}
+}
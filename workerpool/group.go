@@ -0,0 +1,64 @@
+package workerpool
+
+import "context"
+
+// Go runs fn in its own goroutine, blocking until a slot is free so that at
+// most Pool's size worth of Go-spawned goroutines run at once. That limit is
+// the size at the time of the first Go call; a later Resize does not change
+// it. It is a
+// companion to the job-channel model: callers that have ad-hoc work (e.g. one
+// closure per incoming request) rather than a stream of Tasks can still share
+// the pool's concurrency limit.
+//
+// The context passed to the first Go call on a given Pool seeds the group's
+// context, available via Context; it is cancelled as soon as any fn returns a
+// non-nil error. Every call's own ctx is also honored while it waits for a
+// free slot, so a caller with a context that is cancelled or times out
+// independently of the group still aborts promptly instead of waiting for a
+// slot that the group context alone would not free up.
+func (p *Pool[In, Out]) Go(ctx context.Context, fn func() error) error {
+	p.goOnce.Do(func() {
+		p.goCtx, p.goCancel = context.WithCancel(ctx)
+		p.goSem = make(chan struct{}, p.size)
+	})
+
+	select {
+	case p.goSem <- struct{}{}:
+	case <-p.goCtx.Done():
+		return p.goCtx.Err()
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	p.goWG.Add(1)
+	go func() {
+		defer p.goWG.Done()
+		defer func() { <-p.goSem }()
+
+		if err := fn(); err != nil {
+			p.goErrOnce.Do(func() {
+				p.goErr = err
+				p.goCancel()
+			})
+		}
+	}()
+	return nil
+}
+
+// Context returns the group context established by the first call to Go on
+// this Pool. It is cancelled once any Go'd function returns an error, so
+// other in-flight goroutines can select on it to abort early.
+func (p *Pool[In, Out]) Context() context.Context {
+	return p.goCtx
+}
+
+// Wait blocks until every goroutine started with Go has returned, then
+// returns the first non-nil error any of them produced, mirroring
+// errgroup.Group.Wait.
+func (p *Pool[In, Out]) Wait() error {
+	p.goWG.Wait()
+	if p.goCancel != nil {
+		p.goCancel()
+	}
+	return p.goErr
+}
@@ -0,0 +1,83 @@
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestPoolGoLimitsConcurrency(t *testing.T) {
+	p := New[struct{}, struct{}](2)
+
+	var inFlight, maxInFlight int64
+	const n = 10
+	for i := 0; i < n; i++ {
+		p.Go(context.Background(), func() error {
+			cur := atomic.AddInt64(&inFlight, 1)
+			for {
+				max := atomic.LoadInt64(&maxInFlight)
+				if cur <= max || atomic.CompareAndSwapInt64(&maxInFlight, max, cur) {
+					break
+				}
+			}
+			time.Sleep(10 * time.Millisecond)
+			atomic.AddInt64(&inFlight, -1)
+			return nil
+		})
+	}
+	if err := p.Wait(); err != nil {
+		t.Fatalf("Wait: %v", err)
+	}
+	if maxInFlight > 2 {
+		t.Fatalf("expected at most 2 concurrent goroutines, saw %d", maxInFlight)
+	}
+}
+
+func TestPoolGoWaitReturnsFirstError(t *testing.T) {
+	p := New[struct{}, struct{}](4)
+	boom := errors.New("boom")
+
+	p.Go(context.Background(), func() error { return nil })
+	p.Go(context.Background(), func() error { return boom })
+	p.Go(context.Background(), func() error { return nil })
+
+	if err := p.Wait(); err != boom {
+		t.Fatalf("expected %v, got %v", boom, err)
+	}
+}
+
+// TestPoolGoHonorsPerCallContext guards against only the first Go call's
+// context ever being consulted: a later caller passing an already-cancelled
+// ctx must return promptly instead of waiting for a slot to free up.
+func TestPoolGoHonorsPerCallContext(t *testing.T) {
+	p := New[struct{}, struct{}](1)
+
+	blocker := make(chan struct{})
+	if err := p.Go(context.Background(), func() error {
+		<-blocker
+		return nil
+	}); err != nil {
+		t.Fatalf("first Go: %v", err)
+	}
+
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	start := time.Now()
+	err := p.Go(cancelled, func() error {
+		t.Fatalf("fn must not run once its own ctx was already cancelled")
+		return nil
+	})
+	elapsed := time.Since(start)
+	close(blocker)
+	p.Wait()
+
+	if err == nil {
+		t.Fatalf("expected an error from the cancelled per-call ctx")
+	}
+	if elapsed > 200*time.Millisecond {
+		t.Fatalf("Go with an already-cancelled ctx took %v to return; should be immediate", elapsed)
+	}
+}
@@ -0,0 +1,111 @@
+package workerpool
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Middleware wraps a Handler to add cross-cutting behavior (retries,
+// timeouts, panic recovery, logging, tracing, ...) without the Handler
+// itself needing to know about it. Middlewares compose like net/http's:
+// the first one passed to New via an Option runs outermost.
+type Middleware[In, Out any] func(Handler[In, Out]) Handler[In, Out]
+
+// Option configures a Pool at construction time.
+type Option[In, Out any] func(*Pool[In, Out])
+
+// Use registers middleware on the pool directly. Options built from
+// WithRetry/WithTimeout/WithRecover call this; it is exported so callers can
+// add their own (logging, tracing) the same way.
+func (p *Pool[In, Out]) Use(mw Middleware[In, Out]) {
+	p.middlewares = append(p.middlewares, mw)
+}
+
+// wrap composes the registered middlewares around h, with the first
+// registered middleware outermost.
+func (p *Pool[In, Out]) wrap(h Handler[In, Out]) Handler[In, Out] {
+	for i := len(p.middlewares) - 1; i >= 0; i-- {
+		h = p.middlewares[i](h)
+	}
+	return h
+}
+
+// WithRetry retries a failing Handler up to n additional times, waiting
+// backoff(attempt) between attempts (attempt is 0-based). backoff may be nil
+// to retry immediately. Retrying stops early if ctx is cancelled while
+// waiting.
+func WithRetry[In, Out any](n int, backoff func(attempt int) time.Duration) Option[In, Out] {
+	return func(p *Pool[In, Out]) {
+		p.Use(func(next Handler[In, Out]) Handler[In, Out] {
+			return func(ctx context.Context, in In) (Out, error) {
+				out, err := next(ctx, in)
+				for attempt := 0; err != nil && attempt < n; attempt++ {
+					if backoff != nil {
+						select {
+						case <-time.After(backoff(attempt)):
+						case <-ctx.Done():
+							return out, ctx.Err()
+						}
+					}
+					out, err = next(ctx, in)
+				}
+				return out, err
+			}
+		})
+	}
+}
+
+// WithTimeout bounds each call to the Handler to d, returning
+// context.DeadlineExceeded if it runs over. Because the Handler keeps
+// running in the background until it returns, pair this with a Handler that
+// itself respects ctx cancellation to actually abort early. Note that the
+// Handler runs on its own goroutine, so a panic inside it is not caught by a
+// WithRecover registered before WithTimeout (outside it); register
+// WithRecover after WithTimeout so the recovery runs on that goroutine.
+func WithTimeout[In, Out any](d time.Duration) Option[In, Out] {
+	return func(p *Pool[In, Out]) {
+		p.Use(func(next Handler[In, Out]) Handler[In, Out] {
+			return func(ctx context.Context, in In) (Out, error) {
+				ctx, cancel := context.WithTimeout(ctx, d)
+				defer cancel()
+
+				type result struct {
+					out Out
+					err error
+				}
+				done := make(chan result, 1)
+				go func() {
+					out, err := next(ctx, in)
+					done <- result{out, err}
+				}()
+
+				select {
+				case r := <-done:
+					return r.out, r.err
+				case <-ctx.Done():
+					var zero Out
+					return zero, ctx.Err()
+				}
+			}
+		})
+	}
+}
+
+// WithRecover converts a panic inside the Handler into an error, stored on
+// the Task the same way any other error would be, instead of crashing the
+// worker goroutine.
+func WithRecover[In, Out any]() Option[In, Out] {
+	return func(p *Pool[In, Out]) {
+		p.Use(func(next Handler[In, Out]) Handler[In, Out] {
+			return func(ctx context.Context, in In) (out Out, err error) {
+				defer func() {
+					if r := recover(); r != nil {
+						err = fmt.Errorf("workerpool: task panicked: %v", r)
+					}
+				}()
+				return next(ctx, in)
+			}
+		})
+	}
+}
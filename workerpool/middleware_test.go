@@ -0,0 +1,89 @@
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWithRetryAndRecover(t *testing.T) {
+	attempts := 0
+	p := New[int, int](1,
+		WithRecover[int, int](),
+		WithRetry[int, int](2, func(int) time.Duration { return time.Millisecond }),
+	)
+	p.Run(context.Background())
+
+	p.Submit(NewTask(1, func(_ context.Context, in int) (int, error) {
+		attempts++
+		if attempts < 2 {
+			return 0, errors.New("flaky")
+		}
+		return in * 10, nil
+	}))
+	p.Submit(NewTask(2, func(_ context.Context, in int) (int, error) {
+		panic("boom")
+	}))
+	p.Close()
+
+	results := map[int]*Task[int, int]{}
+	for r := range p.Results() {
+		results[r.Input] = r
+	}
+
+	if results[1].Err != nil || results[1].Result != 10 {
+		t.Fatalf("retry case: got %+v", results[1])
+	}
+	if attempts != 2 {
+		t.Fatalf("expected exactly 2 attempts, got %d", attempts)
+	}
+	if results[2].Err == nil {
+		t.Fatalf("recover case: expected an error, got nil")
+	}
+}
+
+func TestWithTimeout(t *testing.T) {
+	p := New[int, int](1, WithTimeout[int, int](20*time.Millisecond))
+	p.Run(context.Background())
+
+	p.Submit(NewTask(1, func(ctx context.Context, in int) (int, error) {
+		select {
+		case <-time.After(100 * time.Millisecond):
+			return in, nil
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		}
+	}))
+	p.Close()
+
+	r := <-p.Results()
+	if r.Err == nil {
+		t.Fatalf("expected a timeout error")
+	}
+}
+
+func TestMiddlewareOrderingRetryWrapsTimeout(t *testing.T) {
+	var attempts int32
+	p := New[int, int](1,
+		WithRetry[int, int](1, func(int) time.Duration { return 0 }),
+		WithTimeout[int, int](10*time.Millisecond),
+	)
+	p.Run(context.Background())
+
+	p.Submit(NewTask(1, func(ctx context.Context, in int) (int, error) {
+		atomic.AddInt32(&attempts, 1)
+		<-ctx.Done()
+		return 0, ctx.Err()
+	}))
+	p.Close()
+	r := <-p.Results()
+
+	if r.Err == nil {
+		t.Fatalf("expected a timeout error to surface after retries are exhausted")
+	}
+	if got := atomic.LoadInt32(&attempts); got != 2 {
+		t.Fatalf("expected each retry attempt to get its own timeout window, got %d attempts", got)
+	}
+}
@@ -0,0 +1,170 @@
+// Package workerpool implements the worker pool concurrency pattern: a
+// resizable group of goroutines that process tasks read from a shared jobs channel,
+// bounding how much work runs at once.
+package workerpool
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+)
+
+// ErrPoolClosed is returned by Submit once the pool has been closed.
+var ErrPoolClosed = errors.New("workerpool: pool is closed")
+
+// Pool runs Tasks across a number of worker goroutines that Resize can change
+// at runtime.
+type Pool[In, Out any] struct {
+	size    int
+	jobs    chan *Task[In, Out]
+	results chan *Task[In, Out]
+	wg      sync.WaitGroup
+
+	// mu guards closed, workers and nextWorkerID together: Submit, Resize
+	// and Close all need to agree on whether the pool is still accepting
+	// work before touching the jobs channel or the live worker set.
+	mu           sync.Mutex
+	closed       bool
+	workers      map[int]chan struct{}
+	nextWorkerID int
+	runCtx       context.Context
+
+	inFlight  int64
+	processed uint64
+
+	middlewares []Middleware[In, Out]
+
+	// goOnce and friends back the Go/Wait semaphore API; they are separate
+	// from the jobs/results machinery above and only initialized on first use.
+	goOnce    sync.Once
+	goCtx     context.Context
+	goCancel  context.CancelFunc
+	goSem     chan struct{}
+	goWG      sync.WaitGroup
+	goErrOnce sync.Once
+	goErr     error
+}
+
+// New creates a Pool that will process tasks using size worker goroutines.
+// Call Run to start the workers. Options such as WithRetry, WithTimeout and
+// WithRecover register middleware that wraps every Task's Handler.
+func New[In, Out any](size int, opts ...Option[In, Out]) *Pool[In, Out] {
+	p := &Pool[In, Out]{
+		size:    size,
+		jobs:    make(chan *Task[In, Out], size),
+		results: make(chan *Task[In, Out], size),
+		workers: make(map[int]chan struct{}, size),
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// Submit enqueues t for processing. It returns ErrPoolClosed if the pool has
+// already been closed.
+func (p *Pool[In, Out]) Submit(t *Task[In, Out]) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.closed {
+		return ErrPoolClosed
+	}
+	p.jobs <- t
+	return nil
+}
+
+// Results returns the channel on which completed tasks are delivered. It is
+// closed once every worker has returned, after Close has been called and all
+// submitted tasks have been processed.
+func (p *Pool[In, Out]) Results() <-chan *Task[In, Out] {
+	return p.results
+}
+
+// Run starts the worker goroutines. Workers stop either when ctx is
+// cancelled or when the pool is closed and the jobs channel drains. The
+// worker count can be changed afterwards with Resize. Results is closed by
+// Close, once it has happened and every worker has returned.
+func (p *Pool[In, Out]) Run(ctx context.Context) {
+	p.mu.Lock()
+	p.runCtx = ctx
+	for i := 0; i < p.size; i++ {
+		p.spawnWorker(ctx)
+	}
+	p.mu.Unlock()
+}
+
+// Resize grows or shrinks the live worker set to n, including down to zero
+// to pause processing entirely. Growing starts new workers reading from the
+// same jobs channel; shrinking signals the excess workers to quit once they
+// finish whatever task they're currently running. Resize must be called
+// after Run, and is a no-op once the pool has been Closed so that a worker
+// can never be spawned after Close has started waiting for the existing
+// ones to finish.
+func (p *Pool[In, Out]) Resize(n int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.closed {
+		return
+	}
+
+	current := len(p.workers)
+	switch {
+	case n > current:
+		for i := 0; i < n-current; i++ {
+			p.spawnWorker(p.runCtx)
+		}
+	case n < current:
+		removed := 0
+		for id, quit := range p.workers {
+			if removed >= current-n {
+				break
+			}
+			close(quit)
+			delete(p.workers, id)
+			removed++
+		}
+	}
+	p.size = n
+}
+
+// Stats reports a snapshot of the pool's current activity.
+type Stats struct {
+	Size      int    // current number of live workers
+	InFlight  int64  // tasks currently being processed
+	Processed uint64 // tasks completed since Run started
+}
+
+// Stats returns a snapshot of the pool's current size and throughput.
+func (p *Pool[In, Out]) Stats() Stats {
+	p.mu.Lock()
+	size := len(p.workers)
+	p.mu.Unlock()
+
+	return Stats{
+		Size:      size,
+		InFlight:  atomic.LoadInt64(&p.inFlight),
+		Processed: atomic.LoadUint64(&p.processed),
+	}
+}
+
+// Close signals that no more tasks will be submitted. Workers finish
+// processing whatever remains in the jobs channel before exiting, after
+// which Results is closed.
+func (p *Pool[In, Out]) Close() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.closed {
+		return
+	}
+	p.closed = true
+	close(p.jobs)
+
+	go func() {
+		p.wg.Wait()
+		close(p.results)
+	}()
+}
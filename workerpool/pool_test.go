@@ -0,0 +1,95 @@
+package workerpool
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestPoolSubmitRun(t *testing.T) {
+	p := New[int, int](3)
+	p.Run(context.Background())
+
+	const n = 20
+	go func() {
+		for i := 0; i < n; i++ {
+			p.Submit(NewTask(i, func(_ context.Context, in int) (int, error) {
+				return in * 2, nil
+			}))
+		}
+		p.Close()
+	}()
+
+	seen := map[int]bool{}
+	for r := range p.Results() {
+		if r.Result != r.Input*2 {
+			t.Fatalf("task %d: want %d, got %d", r.Input, r.Input*2, r.Result)
+		}
+		seen[r.Input] = true
+	}
+	if len(seen) != n {
+		t.Fatalf("expected %d results, got %d", n, len(seen))
+	}
+}
+
+func TestPoolSubmitAfterCloseFails(t *testing.T) {
+	p := New[int, int](1)
+	p.Run(context.Background())
+	p.Close()
+	for range p.Results() {
+	}
+
+	if err := p.Submit(NewTask(1, func(_ context.Context, in int) (int, error) { return in, nil })); err != ErrPoolClosed {
+		t.Fatalf("expected ErrPoolClosed, got %v", err)
+	}
+}
+
+func TestPoolResizeGrowShrink(t *testing.T) {
+	p := New[int, int](2)
+	p.Run(context.Background())
+
+	p.Resize(5)
+	if s := p.Stats(); s.Size != 5 {
+		t.Fatalf("expected size 5, got %d", s.Size)
+	}
+
+	p.Resize(1)
+	time.Sleep(10 * time.Millisecond) // let the signalled workers exit
+	if s := p.Stats(); s.Size != 1 {
+		t.Fatalf("expected size 1, got %d", s.Size)
+	}
+
+	p.Close()
+	for range p.Results() {
+	}
+}
+
+// TestPoolResizeToZeroAndBack guards against Resize(0) permanently closing
+// Results: shrinking the live worker count to zero used to let the
+// wg.Wait()-then-close watcher (previously started unconditionally by Run)
+// observe a momentarily-empty WaitGroup and close Results for good, so any
+// worker grown back in afterwards panicked sending its first result.
+func TestPoolResizeToZeroAndBack(t *testing.T) {
+	p := New[int, int](2)
+	p.Run(context.Background())
+
+	p.Resize(0)
+	time.Sleep(10 * time.Millisecond)
+	p.Resize(2)
+	time.Sleep(10 * time.Millisecond)
+
+	if err := p.Submit(NewTask(1, func(_ context.Context, in int) (int, error) { return in, nil })); err != nil {
+		t.Fatalf("submit: %v", err)
+	}
+
+	select {
+	case r := <-p.Results():
+		if r.Err != nil {
+			t.Fatalf("unexpected err: %v", r.Err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatalf("timed out waiting for a result after Resize(0) then Resize(2)")
+	}
+
+	p.Close()
+}
@@ -0,0 +1,246 @@
+package workerpool
+
+import (
+	"container/heap"
+	"context"
+	"sync"
+	"time"
+)
+
+// agingUnit controls fairness aging: every agingUnit a queued item waits, its
+// effective priority goes up by one, so a steady stream of high-priority
+// submissions cannot starve low-priority ones forever. It also bounds how
+// often queued deadlines are rechecked while workers are otherwise idle.
+const agingUnit = time.Second
+
+// pqItem is one queued task inside a PriorityPool's heap.
+type pqItem[In, Out any] struct {
+	task     *Task[In, Out]
+	priority int
+	enqueued time.Time
+	seq      int // breaks ties FIFO, and backs aging
+}
+
+// priorityQueue is a container/heap.Interface max-heap ordered by priority
+// (ties broken FIFO), with priorities aged by how long an item has waited.
+type priorityQueue[In, Out any] []*pqItem[In, Out]
+
+func (q priorityQueue[In, Out]) effective(i int) int {
+	return q[i].priority + int(time.Since(q[i].enqueued)/agingUnit)
+}
+
+func (q priorityQueue[In, Out]) Len() int { return len(q) }
+func (q priorityQueue[In, Out]) Less(i, j int) bool {
+	pi, pj := q.effective(i), q.effective(j)
+	if pi != pj {
+		return pi > pj
+	}
+	return q[i].seq < q[j].seq
+}
+func (q priorityQueue[In, Out]) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+
+func (q *priorityQueue[In, Out]) Push(x any) {
+	*q = append(*q, x.(*pqItem[In, Out]))
+}
+
+func (q *priorityQueue[In, Out]) Pop() any {
+	old := *q
+	n := len(old)
+	item := old[n-1]
+	old[n-1] = nil
+	*q = old[:n-1]
+	return item
+}
+
+// PriorityPool is an alternative to Pool's plain FIFO jobs channel: queued
+// tasks are dispatched in priority order (aged so low-priority work still
+// eventually runs), and a task can carry a Deadline that expires it while it
+// is still waiting rather than only once a worker picks it up.
+type PriorityPool[In, Out any] struct {
+	size int
+
+	mu      sync.Mutex
+	cond    *sync.Cond
+	items   priorityQueue[In, Out]
+	nextSeq int
+	closed  bool
+
+	closeSignal chan struct{}
+	results     chan *Task[In, Out]
+	wg          sync.WaitGroup
+}
+
+// NewPriorityPool creates a PriorityPool that will process tasks using size
+// worker goroutines. Call Run to start the workers.
+func NewPriorityPool[In, Out any](size int) *PriorityPool[In, Out] {
+	p := &PriorityPool[In, Out]{
+		size:        size,
+		closeSignal: make(chan struct{}),
+		results:     make(chan *Task[In, Out], size),
+	}
+	p.cond = sync.NewCond(&p.mu)
+	return p
+}
+
+// Submit enqueues t with the given priority; higher values run first among
+// tasks of similar age. It returns ErrPoolClosed once Close has been called.
+func (p *PriorityPool[In, Out]) Submit(t *Task[In, Out], priority int) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.closed {
+		return ErrPoolClosed
+	}
+	heap.Push(&p.items, &pqItem[In, Out]{
+		task:     t,
+		priority: priority,
+		enqueued: time.Now(),
+		seq:      p.nextSeq,
+	})
+	p.nextSeq++
+	p.cond.Signal()
+	return nil
+}
+
+// Results returns the channel on which completed and expired tasks are
+// delivered. It is closed once every worker has returned, after Close has
+// been called and the queue has drained.
+func (p *PriorityPool[In, Out]) Results() <-chan *Task[In, Out] {
+	return p.results
+}
+
+// Run starts the worker goroutines, plus a background goroutine that wakes
+// them periodically so deadlines are enforced even while the pool is
+// otherwise idle. Workers stop once ctx is cancelled or the pool is closed
+// and the queue has drained.
+func (p *PriorityPool[In, Out]) Run(ctx context.Context) {
+	for i := 0; i < p.size; i++ {
+		p.wg.Add(1)
+		go func() {
+			defer p.wg.Done()
+			p.runWorker(ctx)
+		}()
+	}
+
+	go p.monitor(ctx)
+
+	go func() {
+		p.wg.Wait()
+		close(p.results)
+	}()
+}
+
+func (p *PriorityPool[In, Out]) runWorker(ctx context.Context) {
+	for {
+		t, ok := p.pop(ctx)
+		if !ok {
+			return
+		}
+		t.run(ctx)
+		p.results <- t
+	}
+}
+
+// monitor periodically nudges pop so queued deadlines are enforced even when
+// no new tasks are being submitted or picked up.
+func (p *PriorityPool[In, Out]) monitor(ctx context.Context) {
+	ticker := time.NewTicker(agingUnit)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			p.mu.Lock()
+			p.cond.Broadcast()
+			p.mu.Unlock()
+		case <-ctx.Done():
+			p.mu.Lock()
+			p.cond.Broadcast()
+			p.mu.Unlock()
+			return
+		case <-p.closeSignal:
+			return
+		}
+	}
+}
+
+// pop blocks until a task is available, the pool is closed with an empty
+// queue, or ctx is cancelled. Items past their Deadline are dropped (with
+// Err set to context.DeadlineExceeded and delivered to Results) instead of
+// being returned to run.
+func (p *PriorityPool[In, Out]) pop(ctx context.Context) (*Task[In, Out], bool) {
+	p.mu.Lock()
+	for {
+		if ctx.Err() != nil {
+			p.mu.Unlock()
+			return nil, false
+		}
+
+		if expired := p.expireLocked(); len(expired) > 0 {
+			p.mu.Unlock()
+			for _, t := range expired {
+				p.results <- t
+			}
+			p.mu.Lock()
+			continue
+		}
+
+		if len(p.items) > 0 {
+			item := heap.Pop(&p.items).(*pqItem[In, Out])
+			p.mu.Unlock()
+			return item.task, true
+		}
+		if p.closed {
+			p.mu.Unlock()
+			return nil, false
+		}
+		p.cond.Wait()
+	}
+}
+
+// expireLocked removes items past their Deadline from the queue and returns
+// their tasks, each with Err set to context.DeadlineExceeded. Callers must
+// hold p.mu and must not send on p.results while still holding it.
+func (p *PriorityPool[In, Out]) expireLocked() []*Task[In, Out] {
+	now := time.Now()
+	firstExpired := -1
+	for i, item := range p.items {
+		if !item.task.Deadline.IsZero() && now.After(item.task.Deadline) {
+			firstExpired = i
+			break
+		}
+	}
+	if firstExpired == -1 {
+		return nil
+	}
+
+	var expired []*Task[In, Out]
+	kept := p.items[:0]
+	for _, item := range p.items {
+		if !item.task.Deadline.IsZero() && now.After(item.task.Deadline) {
+			item.task.Err = context.DeadlineExceeded
+			expired = append(expired, item.task)
+			continue
+		}
+		kept = append(kept, item)
+	}
+	p.items = kept
+	heap.Init(&p.items)
+	return expired
+}
+
+// Close signals that no more tasks will be submitted. Workers drain whatever
+// remains in the queue, honoring priority, aging and deadlines, before
+// exiting.
+func (p *PriorityPool[In, Out]) Close() {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return
+	}
+	p.closed = true
+	p.cond.Broadcast()
+	p.mu.Unlock()
+
+	close(p.closeSignal)
+}
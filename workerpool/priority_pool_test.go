@@ -0,0 +1,109 @@
+package workerpool
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestPriorityPoolOrdersByPriority(t *testing.T) {
+	p := NewPriorityPool[int, int](1)
+	p.Run(context.Background())
+
+	blocker := make(chan struct{})
+	p.Submit(NewTask(0, func(_ context.Context, in int) (int, error) {
+		<-blocker
+		return in, nil
+	}), 0)
+	time.Sleep(10 * time.Millisecond) // let the worker pick up task 0 before the rest queue up
+
+	var order []int
+	submit := func(id, prio int) {
+		p.Submit(NewTask(id, func(_ context.Context, in int) (int, error) {
+			order = append(order, in)
+			return in, nil
+		}), prio)
+	}
+	submit(1, 1)
+	submit(2, 5)
+	submit(3, 3)
+
+	close(blocker)
+	p.Close()
+	for range p.Results() {
+	}
+
+	if len(order) != 3 || order[0] != 2 || order[1] != 3 || order[2] != 1 {
+		t.Fatalf("expected priority order [2 3 1], got %v", order)
+	}
+}
+
+func TestPriorityPoolDeadlineExpiresWhileQueued(t *testing.T) {
+	p := NewPriorityPool[int, int](1)
+
+	blocker := make(chan struct{})
+	p.Submit(NewTask(0, func(_ context.Context, in int) (int, error) {
+		<-blocker
+		return in, nil
+	}), 10)
+
+	expiring := NewTask(1, func(_ context.Context, in int) (int, error) { return in, nil })
+	expiring.Deadline = time.Now().Add(5 * time.Millisecond)
+	p.Submit(expiring, 1)
+
+	p.Run(context.Background())
+	time.Sleep(1100 * time.Millisecond) // hold task 0 well past task 1's deadline; the worker expires task 1 on its next pop
+	close(blocker)
+	p.Close()
+
+	byInput := map[int]*Task[int, int]{}
+	for r := range p.Results() {
+		byInput[r.Input] = r
+	}
+	if len(byInput) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(byInput))
+	}
+	if byInput[0].Err != nil {
+		t.Fatalf("task 0 should have run normally, got err %v", byInput[0].Err)
+	}
+	if byInput[1].Err != context.DeadlineExceeded {
+		t.Fatalf("expected task 1 to expire with DeadlineExceeded, got %v", byInput[1].Err)
+	}
+}
+
+func TestPriorityPoolStopsPromptlyOnCancel(t *testing.T) {
+	p := NewPriorityPool[int, int](1)
+
+	blocker := make(chan struct{})
+	p.Submit(NewTask(0, func(_ context.Context, in int) (int, error) {
+		<-blocker
+		return in, nil
+	}), 0)
+	for i := 1; i <= 50; i++ {
+		p.Submit(NewTask(i, func(_ context.Context, in int) (int, error) { return in, nil }), 0)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	p.Run(ctx)
+	time.Sleep(10 * time.Millisecond)
+	cancel()
+	time.Sleep(10 * time.Millisecond)
+	close(blocker)
+
+	done := make(chan int)
+	go func() {
+		count := 0
+		for range p.Results() {
+			count++
+		}
+		done <- count
+	}()
+	select {
+	case count := <-done:
+		if count >= 51 {
+			t.Fatalf("expected cancellation to stop draining the queue, got all %d results", count)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatalf("workers did not stop promptly after ctx cancellation")
+	}
+}
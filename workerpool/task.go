@@ -0,0 +1,38 @@
+package workerpool
+
+import (
+	"context"
+	"time"
+)
+
+// Handler processes an input value and produces a result or an error.
+type Handler[In, Out any] func(ctx context.Context, in In) (Out, error)
+
+// Task represents a single unit of work submitted to a Pool. Once the pool
+// has run it, Result and Err hold the outcome.
+type Task[In, Out any] struct {
+	Input   In
+	Handler Handler[In, Out]
+
+	// Deadline is optional and only honored by PriorityPool: a task still
+	// waiting in the queue past its Deadline is dropped with Err set to
+	// context.DeadlineExceeded instead of being run. The zero value means
+	// no deadline.
+	Deadline time.Time
+
+	Result Out
+	Err    error
+}
+
+// NewTask creates a Task that will invoke handler with in when run by a Pool.
+func NewTask[In, Out any](in In, handler Handler[In, Out]) *Task[In, Out] {
+	return &Task[In, Out]{
+		Input:   in,
+		Handler: handler,
+	}
+}
+
+// run invokes the task's handler and stores the outcome on the task itself.
+func (t *Task[In, Out]) run(ctx context.Context) {
+	t.Result, t.Err = t.Handler(ctx, t.Input)
+}
@@ -0,0 +1,55 @@
+package workerpool
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+// worker pulls tasks from p.jobs until the channel is closed, quit is
+// signalled, or ctx is done, running each one and publishing it on
+// p.results. quit lets Resize shut down an individual worker without
+// touching the shared jobs channel.
+func (p *Pool[In, Out]) worker(ctx context.Context, quit <-chan struct{}) {
+	for {
+		select {
+		case t, ok := <-p.jobs:
+			if !ok {
+				return
+			}
+			atomic.AddInt64(&p.inFlight, 1)
+			h := t.Handler
+			if len(p.middlewares) > 0 {
+				h = p.wrap(h)
+			}
+			t.Result, t.Err = h(ctx, t.Input)
+			atomic.AddInt64(&p.inFlight, -1)
+			atomic.AddUint64(&p.processed, 1)
+			p.results <- t
+		case <-quit:
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// spawnWorker registers a new worker under its own id and starts it. Callers
+// must hold p.mu.
+func (p *Pool[In, Out]) spawnWorker(ctx context.Context) {
+	id := p.nextWorkerID
+	p.nextWorkerID++
+
+	quit := make(chan struct{})
+	p.workers[id] = quit
+
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		defer func() {
+			p.mu.Lock()
+			delete(p.workers, id)
+			p.mu.Unlock()
+		}()
+		p.worker(ctx, quit)
+	}()
+}